@@ -0,0 +1,282 @@
+package assertly
+
+import (
+	"github.com/viant/toolbox"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+//defaultTimeLayout is assumed when a directive does not declare its own "@timeLayout@"
+const defaultTimeLayout = "2006-01-02 15:04:05"
+
+const (
+	indexByDirectiveKey         = "@indexBy@"
+	switchByDirectiveKey        = "@switchBy@"
+	keyExistsDirectiveKey       = "@keyExists@"
+	keyDoesNotExistDirectiveKey = "@keyDoesNotExist@"
+	sourceDirectiveKey          = "@source@"
+	sortTextDirectiveKey        = "@sortText@"
+	caseSensitiveDirectiveKey   = "@caseSensitive@"
+	timeLayoutDirectiveKey      = "@timeLayout@"
+)
+
+//directiveKeyExpression matches a bare "@name@" control key, as opposed to a "@name@<field>" per-field one
+var directiveKeyExpression = regexp.MustCompile(`^@[a-zA-Z]+@$`)
+
+//Directive captures the "@xxx@" control keys that steer how a map or slice is asserted
+type Directive struct {
+	IndexBy         []string
+	SwitchBy        []string
+	KeyExists       map[string]bool
+	KeyDoesNotExist map[string]bool
+	Source          string
+	SortText        bool
+	CaseSensitive   bool
+	timeLayout      string
+	dataTypes       map[string]reflect.Type
+
+	//Delta holds per-field absolute tolerance populated from "@delta@<field>" keys on expected, e.g. "@delta@amount": 0.01
+	Delta map[string]float64
+	//Epsilon holds per-field relative tolerance populated from "@epsilon@<field>" keys on expected
+	Epsilon map[string]float64
+
+	//ElementsMatch, when true, enables order-independent multiset comparison for the slice
+	ElementsMatch bool
+	//ElementsMatchFields holds, per field, whether that field's slice should be compared order-independently
+	ElementsMatchFields map[string]bool
+
+	//Format holds, per field, the structural format ("json"/"yaml"/"xml") its string value should be decoded as
+	Format map[string]string
+}
+
+//NewDirective creates an empty directive defaulting to case-sensitive comparison; path is not stored
+func NewDirective(path DataPath) *Directive {
+	return &Directive{CaseSensitive: true}
+}
+
+//DefaultTimeLayout returns the time layout declared via "@timeLayout@", or defaultTimeLayout if none was declared
+func (d *Directive) DefaultTimeLayout() string {
+	if d.timeLayout == "" {
+		return defaultTimeLayout
+	}
+	return d.timeLayout
+}
+
+//mergeFrom copies IndexBy, SwitchBy, Source, SortText and the time layout from other into d wherever d does not already carry a value of its own; other settings are not copied
+func (d *Directive) mergeFrom(other *Directive) {
+	if other == nil {
+		return
+	}
+	if len(d.IndexBy) == 0 {
+		d.IndexBy = other.IndexBy
+	}
+	if len(d.SwitchBy) == 0 {
+		d.SwitchBy = other.SwitchBy
+	}
+	if d.Source == "" {
+		d.Source = other.Source
+	}
+	if !d.SortText {
+		d.SortText = other.SortText
+	}
+	if d.timeLayout == "" {
+		d.timeLayout = other.timeLayout
+	}
+}
+
+//IsDirectiveKey reports whether key is a bare "@xxx@" control key rather than a literal field name
+func (d *Directive) IsDirectiveKey(key string) bool {
+	return directiveKeyExpression.MatchString(key)
+}
+
+//asStringSlice normalizes a directive value that may be declared as a single scalar or a slice into a []string
+func asStringSlice(value interface{}) []string {
+	if value == nil {
+		return nil
+	}
+	if !toolbox.IsSlice(value) {
+		return []string{toolbox.AsString(value)}
+	}
+	var result []string
+	toolbox.ProcessSlice(toolbox.AsSlice(value), func(item interface{}) bool {
+		result = append(result, toolbox.AsString(item))
+		return true
+	})
+	return result
+}
+
+//asKeySet turns a directive value (scalar or slice of field names) into a set for O(1) membership checks
+func asKeySet(value interface{}) map[string]bool {
+	result := make(map[string]bool)
+	for _, key := range asStringSlice(value) {
+		result[key] = true
+	}
+	return result
+}
+
+//ExtractDirectives pulls the bare "@xxx@" control keys out of expected, populating the matching Directive fields
+func (d *Directive) ExtractDirectives(expected map[string]interface{}) bool {
+	var extracted bool
+	if value, ok := expected[indexByDirectiveKey]; ok {
+		d.IndexBy = asStringSlice(value)
+		delete(expected, indexByDirectiveKey)
+		extracted = true
+	}
+	if value, ok := expected[switchByDirectiveKey]; ok {
+		d.SwitchBy = asStringSlice(value)
+		delete(expected, switchByDirectiveKey)
+		extracted = true
+	}
+	if value, ok := expected[keyExistsDirectiveKey]; ok {
+		d.KeyExists = asKeySet(value)
+		delete(expected, keyExistsDirectiveKey)
+		extracted = true
+	}
+	if value, ok := expected[keyDoesNotExistDirectiveKey]; ok {
+		d.KeyDoesNotExist = asKeySet(value)
+		delete(expected, keyDoesNotExistDirectiveKey)
+		extracted = true
+	}
+	if value, ok := expected[sourceDirectiveKey]; ok {
+		d.Source = toolbox.AsString(value)
+		delete(expected, sourceDirectiveKey)
+		extracted = true
+	}
+	if value, ok := expected[sortTextDirectiveKey]; ok {
+		d.SortText = toolbox.AsBoolean(value)
+		delete(expected, sortTextDirectiveKey)
+		extracted = true
+	}
+	if value, ok := expected[caseSensitiveDirectiveKey]; ok {
+		d.CaseSensitive = toolbox.AsBoolean(value)
+		delete(expected, caseSensitiveDirectiveKey)
+		extracted = true
+	}
+	if value, ok := expected[timeLayoutDirectiveKey]; ok {
+		d.timeLayout = toolbox.AsString(value)
+		delete(expected, timeLayoutDirectiveKey)
+		extracted = true
+	}
+	return extracted
+}
+
+//ExtractDataTypes records the concrete Go type backing each field of v, so Apply can later coerce onto it
+func (d *Directive) ExtractDataTypes(v interface{}) {
+	m := toolbox.AsMap(v)
+	if len(m) == 0 {
+		return
+	}
+	if d.dataTypes == nil {
+		d.dataTypes = make(map[string]reflect.Type)
+	}
+	for key, value := range m {
+		if value == nil {
+			continue
+		}
+		d.dataTypes[key] = reflect.TypeOf(value)
+	}
+}
+
+//Add augments the directive's known field data types with any fields found on m not already known
+func (d *Directive) Add(m map[string]interface{}) {
+	if len(m) == 0 {
+		return
+	}
+	if d.dataTypes == nil {
+		d.dataTypes = make(map[string]reflect.Type)
+	}
+	for key, value := range m {
+		if value == nil {
+			continue
+		}
+		if _, ok := d.dataTypes[key]; ok {
+			continue
+		}
+		d.dataTypes[key] = reflect.TypeOf(value)
+	}
+}
+
+//Apply coerces v's fields onto the data types previously captured via ExtractDataTypes/Add
+func (d *Directive) Apply(v interface{}) error {
+	m := toolbox.AsMap(v)
+	if len(m) == 0 || len(d.dataTypes) == 0 {
+		return nil
+	}
+	for key, value := range m {
+		targetType, ok := d.dataTypes[key]
+		if !ok || value == nil || reflect.TypeOf(value) == targetType {
+			continue
+		}
+		switch targetType.Kind() {
+		case reflect.Float32, reflect.Float64:
+			m[key] = toolbox.AsFloat(value)
+		case reflect.Bool:
+			m[key] = toolbox.AsBoolean(value)
+		case reflect.String:
+			m[key] = toolbox.AsString(value)
+		default:
+			if intValue, err := toolbox.ToInt(value); err == nil {
+				m[key] = intValue
+			}
+		}
+	}
+	return nil
+}
+
+//ApplyCaseInsensitive marks the directive case-insensitive and uppercases the field names it already captured
+func (d *Directive) ApplyCaseInsensitive() {
+	d.CaseSensitive = false
+	d.IndexBy = upperStrings(d.IndexBy)
+	d.SwitchBy = upperStrings(d.SwitchBy)
+	d.KeyExists = upperKeySet(d.KeyExists)
+	d.KeyDoesNotExist = upperKeySet(d.KeyDoesNotExist)
+	d.Delta = upperFloatKeys(d.Delta)
+	d.Epsilon = upperFloatKeys(d.Epsilon)
+	d.ElementsMatchFields = upperKeySet(d.ElementsMatchFields)
+	d.Format = upperStringKeys(d.Format)
+}
+
+func upperStrings(values []string) []string {
+	if len(values) == 0 {
+		return values
+	}
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = strings.ToUpper(value)
+	}
+	return result
+}
+
+func upperKeySet(values map[string]bool) map[string]bool {
+	if len(values) == 0 {
+		return values
+	}
+	result := make(map[string]bool, len(values))
+	for key, value := range values {
+		result[strings.ToUpper(key)] = value
+	}
+	return result
+}
+
+func upperFloatKeys(values map[string]float64) map[string]float64 {
+	if len(values) == 0 {
+		return values
+	}
+	result := make(map[string]float64, len(values))
+	for key, value := range values {
+		result[strings.ToUpper(key)] = value
+	}
+	return result
+}
+
+func upperStringKeys(values map[string]string) map[string]string {
+	if len(values) == 0 {
+		return values
+	}
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		result[strings.ToUpper(key)] = value
+	}
+	return result
+}
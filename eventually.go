@@ -0,0 +1,97 @@
+package assertly
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"testing"
+	"time"
+)
+
+const (
+	//RetryDirectiveKey declares an inline timeout/interval for AssertEventually directly on expected fixture data
+	RetryDirectiveKey = "@retry@"
+	//DefaultEventuallyTimeout is used when AssertEventually is called with a zero timeout
+	DefaultEventuallyTimeout = 5 * time.Second
+	//DefaultEventuallyInterval is used when AssertEventually is called with a zero interval
+	DefaultEventuallyInterval = 100 * time.Millisecond
+)
+
+//extractRetryDirective reads the @retry@ key off the root of expected, returning it removed along with timeout/interval
+func extractRetryDirective(expected interface{}, timeout, interval time.Duration) (interface{}, time.Duration, time.Duration) {
+	if !toolbox.IsMap(expected) {
+		return expected, timeout, interval
+	}
+	expectedMap := toolbox.AsMap(expected)
+	raw, ok := expectedMap[RetryDirectiveKey]
+	if !ok {
+		return expected, timeout, interval
+	}
+	if toolbox.IsMap(raw) {
+		retryMap := toolbox.AsMap(raw)
+		if text, ok := retryMap["timeout"]; ok {
+			if parsed, err := time.ParseDuration(toolbox.AsString(text)); err == nil {
+				timeout = parsed
+			}
+		}
+		if text, ok := retryMap["interval"]; ok {
+			if parsed, err := time.ParseDuration(toolbox.AsString(text)); err == nil {
+				interval = parsed
+			}
+		}
+	}
+	delete(expectedMap, RetryDirectiveKey)
+	return expectedMap, timeout, interval
+}
+
+//AssertEventually re-invokes actualFn on interval ticks until expected matches or timeout elapses
+func AssertEventually(expected interface{}, actualFn func() (interface{}, error), path DataPath, timeout, interval time.Duration) (*Validation, error) {
+	if timeout <= 0 {
+		timeout = DefaultEventuallyTimeout
+	}
+	if interval <= 0 {
+		interval = DefaultEventuallyInterval
+	}
+	expected, timeout, interval = extractRetryDirective(expected, timeout, interval)
+
+	deadline := time.Now().Add(timeout)
+	var validation *Validation
+	var err error
+	attempts := 0
+	start := time.Now()
+	for {
+		attempts++
+		actual, actualErr := actualFn()
+		if actualErr != nil {
+			err = actualErr
+		} else {
+			validation, err = Assert(expected, actual, path)
+			if err == nil && len(validation.Failures) == 0 {
+				return validation, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(interval)
+	}
+	if validation == nil {
+		validation = NewValidation()
+	}
+	validation.AddFailure(NewFailure(path.Source(), path.Path(), EqualViolation,
+		fmt.Sprintf("did not pass after %v attempts in %v", attempts, time.Since(start)), nil))
+	return validation, err
+}
+
+//AssertEventuallyWithTesting runs AssertEventually and fails t if the assertion never passes within timeout
+func AssertEventuallyWithTesting(t *testing.T, expected interface{}, actualFn func() (interface{}, error), path DataPath, timeout, interval time.Duration) bool {
+	validation, err := AssertEventually(expected, actualFn, path, timeout, interval)
+	if err != nil {
+		handleFailure(t, validation, err)
+		return false
+	}
+	if len(validation.Failures) > 0 {
+		handleFailure(t, validation)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package assertly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtractRetryDirective(t *testing.T) {
+	expected := map[string]interface{}{
+		"status": "done",
+		RetryDirectiveKey: map[string]interface{}{
+			"timeout":  "2s",
+			"interval": "50ms",
+		},
+	}
+	cleaned, timeout, interval := extractRetryDirective(expected, 0, 0)
+	if timeout != 2*time.Second {
+		t.Errorf("expected timeout 2s, got %v", timeout)
+	}
+	if interval != 50*time.Millisecond {
+		t.Errorf("expected interval 50ms, got %v", interval)
+	}
+	cleanedMap, ok := cleaned.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected cleaned expected to remain a map")
+	}
+	if _, ok := cleanedMap[RetryDirectiveKey]; ok {
+		t.Errorf("expected %v to be removed from expected so it is never asserted against actual", RetryDirectiveKey)
+	}
+	if cleanedMap["status"] != "done" {
+		t.Errorf("expected unrelated fields to be left untouched")
+	}
+}
+
+func TestExtractRetryDirectiveAbsent(t *testing.T) {
+	expected := map[string]interface{}{"status": "done"}
+	cleaned, timeout, interval := extractRetryDirective(expected, 5*time.Second, 100*time.Millisecond)
+	if timeout != 5*time.Second || interval != 100*time.Millisecond {
+		t.Errorf("expected defaults to be left untouched when @retry@ is absent")
+	}
+	if _, ok := cleaned.(map[string]interface{})["status"]; !ok {
+		t.Errorf("expected map to be returned unchanged")
+	}
+}
+
+func TestAssertEventuallyConvergesWithinTimeout(t *testing.T) {
+	attempts := 0
+	actualFn := func() (interface{}, error) {
+		attempts++
+		if attempts < 3 {
+			return map[string]interface{}{"status": "pending"}, nil
+		}
+		return map[string]interface{}{"status": "done"}, nil
+	}
+	expected := map[string]interface{}{"status": "done"}
+	validation, err := AssertEventually(expected, actualFn, NewDataPath(""), time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) != 0 {
+		t.Errorf("expected AssertEventually to converge once actualFn reports done, got failures: %v", validation.Failures)
+	}
+	if attempts < 3 {
+		t.Errorf("expected actualFn to be polled until it converged, got %v attempts", attempts)
+	}
+}
+
+func TestAssertEventuallyTimesOut(t *testing.T) {
+	actualFn := func() (interface{}, error) {
+		return map[string]interface{}{"status": "pending"}, nil
+	}
+	expected := map[string]interface{}{"status": "done"}
+	validation, err := AssertEventually(expected, actualFn, NewDataPath(""), 30*time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) == 0 {
+		t.Errorf("expected AssertEventually to fail once the timeout elapses without convergence")
+	}
+}
+
+func TestAssertEventuallyUsesRetryDirective(t *testing.T) {
+	attempts := 0
+	actualFn := func() (interface{}, error) {
+		attempts++
+		if attempts < 2 {
+			return map[string]interface{}{"status": "pending"}, nil
+		}
+		return map[string]interface{}{"status": "done"}, nil
+	}
+	expected := map[string]interface{}{
+		"status": "done",
+		RetryDirectiveKey: map[string]interface{}{
+			"timeout":  "1s",
+			"interval": "10ms",
+		},
+	}
+	validation, err := AssertEventually(expected, actualFn, NewDataPath(""), 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) != 0 {
+		t.Errorf("expected the @retry@ directive's timeout/interval to drive AssertEventually, got failures: %v", validation.Failures)
+	}
+}
@@ -0,0 +1,133 @@
+package assertly
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/viant/toolbox"
+	"gopkg.in/yaml.v2"
+	"strings"
+)
+
+const (
+	//FormatJSON decodes a string node as JSON before comparison
+	FormatJSON = "json"
+	//FormatYAML decodes a string node as YAML before comparison
+	FormatYAML = "yaml"
+	//FormatXML decodes a string node as XML before comparison
+	FormatXML = "xml"
+)
+
+//formatDirectivePrefix marks a per-field format key on an expected map, e.g. "@format@payload": "json"
+const formatDirectivePrefix = "@format@"
+
+//extractFormatDirectives populates directive.Format from "@format@<field>" keys on expected
+func extractFormatDirectives(directive *Directive, expected map[string]interface{}) {
+	extractFieldDirective(expected, formatDirectivePrefix, func(field string, value interface{}) {
+		if directive.Format == nil {
+			directive.Format = make(map[string]string)
+		}
+		directive.Format[field] = toolbox.AsString(value)
+	})
+}
+
+//decodeFormatted parses text as the named format, canonicalizing it into plain maps/slices/scalars
+func decodeFormatted(format string, text string) (interface{}, error) {
+	switch format {
+	case FormatJSON:
+		var data interface{}
+		if err := json.Unmarshal([]byte(text), &data); err != nil {
+			return nil, fmt.Errorf("failed to parse @format@json: %v", err)
+		}
+		return canonicalize(data), nil
+	case FormatYAML:
+		var data interface{}
+		if err := yaml.Unmarshal([]byte(text), &data); err != nil {
+			return nil, fmt.Errorf("failed to parse @format@yaml: %v", err)
+		}
+		return canonicalize(data), nil
+	case FormatXML:
+		var node xmlNode
+		if err := xml.Unmarshal([]byte(text), &node); err != nil {
+			return nil, fmt.Errorf("failed to parse @format@xml: %v", err)
+		}
+		return canonicalize(node.asMap()), nil
+	}
+	return nil, fmt.Errorf("unsupported @format@ value: %v", format)
+}
+
+//canonicalize normalizes decoded data so byte-identical serializations aren't required
+func canonicalize(value interface{}) interface{} {
+	switch actual := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			result[toolbox.AsString(k)] = canonicalize(v)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			result[k] = canonicalize(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(actual))
+		for i, v := range actual {
+			result[i] = canonicalize(v)
+		}
+		return result
+	case int, int32, int64, float32:
+		return toolbox.AsFloat(actual)
+	default:
+		return actual
+	}
+}
+
+//xmlNode is a generic XML tree used to decode arbitrary documents for @format@xml comparison
+type xmlNode struct {
+	XMLName  xml.Name
+	Content  string    `xml:",chardata"`
+	Children []xmlNode `xml:",any"`
+}
+
+func (n *xmlNode) asMap() interface{} {
+	if len(n.Children) == 0 {
+		return trimmed(n.Content)
+	}
+	var tagCounts = make(map[string]int)
+	for _, child := range n.Children {
+		tagCounts[child.XMLName.Local]++
+	}
+	result := make(map[string]interface{})
+	for _, child := range n.Children {
+		tag := child.XMLName.Local
+		value := child.asMap()
+		if tagCounts[tag] == 1 {
+			result[tag] = value
+			continue
+		}
+		items, _ := result[tag].([]interface{})
+		result[tag] = append(items, value)
+	}
+	return result
+}
+
+func trimmed(text string) string {
+	return strings.TrimSpace(text)
+}
+
+//assertFormatted decodes expected and actual as the named format and recurses via assertValue on the decoded trees
+func assertFormatted(format string, expected, actual interface{}, path DataPath, context *Context, validation *Validation) error {
+	expectedDecoded, err := decodeFormatted(format, toolbox.AsString(expected))
+	if err != nil {
+		validation.AddFailure(NewFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actual, err.Error()))
+		return nil
+	}
+	actualDecoded, err := decodeFormatted(format, toolbox.AsString(actual))
+	if err != nil {
+		validation.AddFailure(NewFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actual, err.Error()))
+		return nil
+	}
+	return assertValue(expectedDecoded, actualDecoded, path, context, validation)
+}
@@ -0,0 +1,99 @@
+package assertly
+
+import (
+	"testing"
+)
+
+func TestDecodeFormatted(t *testing.T) {
+	var useCases = []struct {
+		description string
+		format      string
+		text        string
+		hasError    bool
+	}{
+		{"valid json", FormatJSON, `{"a":1}`, false},
+		{"invalid json", FormatJSON, `{`, true},
+		{"valid yaml", FormatYAML, "a: 1\n", false},
+		{"valid xml", FormatXML, `<root><a>1</a></root>`, false},
+		{"invalid xml", FormatXML, `<root>`, true},
+	}
+	for _, useCase := range useCases {
+		_, err := decodeFormatted(useCase.format, useCase.text)
+		if useCase.hasError && err == nil {
+			t.Errorf("[%v] expected error but got none", useCase.description)
+		}
+		if !useCase.hasError && err != nil {
+			t.Errorf("[%v] expected no error but got %v", useCase.description, err)
+		}
+	}
+}
+
+func TestDecodeFormattedXMLRepeatedTags(t *testing.T) {
+	text := `<root><items><item>a</item><item>b</item><item>c</item></items></root>`
+	decoded, err := decodeFormatted(FormatXML, text)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	root, ok := decoded.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected root to decode as a map, got %T", decoded)
+	}
+	items, ok := root["items"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected items to decode as a map, got %T", root["items"])
+	}
+	item, ok := items["item"].([]interface{})
+	if !ok {
+		t.Fatalf("expected repeated <item> tags to decode as a slice, got %T", items["item"])
+	}
+	if len(item) != 3 || item[0] != "a" || item[1] != "b" || item[2] != "c" {
+		t.Errorf("expected [a b c], got %v", item)
+	}
+}
+
+func TestAssertFormatDirectiveXML(t *testing.T) {
+	expected := map[string]interface{}{
+		"payload":        `<root><items><item>a</item><item>b</item></items></root>`,
+		"@format@payload": FormatXML,
+	}
+	actual := map[string]interface{}{
+		"payload": `<root><items><item>a</item><item>b</item></items></root>`,
+	}
+	validation, err := Assert(expected, actual, NewDataPath(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) != 0 {
+		t.Errorf("expected matching @format@xml payloads to pass, got failures: %v", validation.Failures)
+	}
+
+	mismatched := map[string]interface{}{
+		"payload": `<root><items><item>a</item><item>x</item></items></root>`,
+	}
+	//the first Assert consumed the directive key, so it is declared again
+	expected["@format@payload"] = FormatXML
+	validation, err = Assert(expected, mismatched, NewDataPath(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) == 0 {
+		t.Errorf("expected differing @format@xml payloads to fail")
+	}
+}
+
+func TestExtractFormatDirectiveKey(t *testing.T) {
+	expected := map[string]interface{}{
+		"payload":        `{"a":1}`,
+		"@format@payload": "json",
+	}
+	var formats = make(map[string]string)
+	extractFieldDirective(expected, formatDirectivePrefix, func(field string, value interface{}) {
+		formats[field] = value.(string)
+	})
+	if _, ok := expected["@format@payload"]; ok {
+		t.Errorf("expected @format@payload to be removed from expected map")
+	}
+	if formats["payload"] != "json" {
+		t.Errorf("expected format for payload to be json, got %v", formats["payload"])
+	}
+}
@@ -0,0 +1,122 @@
+package assertly
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+//ErrorIsPredicate passes when actual is an error matching Target via errors.Is, mirroring testify's ErrorIs
+type ErrorIsPredicate struct {
+	Target error
+}
+
+//Apply implements toolbox.Predicate
+func (p *ErrorIsPredicate) Apply(actual interface{}) bool {
+	err, ok := actual.(error)
+	if !ok {
+		return false
+	}
+	return errors.Is(err, p.Target)
+}
+
+//ErrorAsPredicate passes when actual is an error whose chain contains a value assignable to TargetType
+type ErrorAsPredicate struct {
+	TargetType reflect.Type
+	Expected   interface{}
+}
+
+//Apply implements toolbox.Predicate
+func (p *ErrorAsPredicate) Apply(actual interface{}) bool {
+	err, ok := actual.(error)
+	if !ok {
+		return false
+	}
+	target := reflect.New(p.TargetType)
+	if !errors.As(err, target.Interface()) {
+		return false
+	}
+	if p.Expected == nil {
+		return true
+	}
+	validation := NewValidation()
+	if assertErr := assertValue(p.Expected, target.Elem().Interface(), NewDataPath(""), NewDefaultContext(), validation); assertErr != nil {
+		return false
+	}
+	return len(validation.Failures) == 0
+}
+
+//errorMessagePredicate is the @errorIs@ macro's fallback for names not registered in wellKnownErrors
+type errorMessagePredicate struct {
+	Message string
+}
+
+//Apply implements toolbox.Predicate
+func (p *errorMessagePredicate) Apply(actual interface{}) bool {
+	err, ok := actual.(error)
+	if !ok {
+		return false
+	}
+	for err != nil {
+		if err.Error() == p.Message {
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
+
+//PanicsWithPredicate expects actual to be a func() and passes when invoking it panics with a value matching Expected
+type PanicsWithPredicate struct {
+	Expected interface{}
+}
+
+//Apply implements toolbox.Predicate
+func (p *PanicsWithPredicate) Apply(actual interface{}) (result bool) {
+	fn, ok := actual.(func())
+	if !ok {
+		return false
+	}
+	var recovered interface{}
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		fn()
+	}()
+	if recovered == nil {
+		return false
+	}
+	validation := NewValidation()
+	if err := assertValue(p.Expected, recovered, NewDataPath(""), NewDefaultContext(), validation); err != nil {
+		return false
+	}
+	return len(validation.Failures) == 0
+}
+
+const (
+	errorIsMacro    = "@errorIs@"
+	panicsWithMacro = "@panicsWith@"
+)
+
+//wellKnownErrors resolves stdlib sentinel errors by their qualified name; only "io.EOF" is registered
+var wellKnownErrors = map[string]error{
+	"io.EOF": io.EOF,
+}
+
+//expandPredicateMacro resolves textual predicate forms, e.g. "@errorIs@ io.EOF", consulted after context.Evaluator
+func expandPredicateMacro(text string) (interface{}, bool) {
+	switch {
+	case strings.HasPrefix(text, errorIsMacro):
+		name := strings.TrimSpace(strings.TrimPrefix(text, errorIsMacro))
+		if target, ok := wellKnownErrors[name]; ok {
+			return &ErrorIsPredicate{Target: target}, true
+		}
+		return &errorMessagePredicate{Message: name}, true
+	case strings.HasPrefix(text, panicsWithMacro):
+		expected := strings.TrimSpace(strings.TrimPrefix(text, panicsWithMacro))
+		return &PanicsWithPredicate{Expected: expected}, true
+	}
+	return nil, false
+}
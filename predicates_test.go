@@ -0,0 +1,100 @@
+package assertly
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+)
+
+//customError is a concrete error type used to exercise ErrorAsPredicate's errors.As matching
+type customError struct {
+	Code int
+}
+
+func (e *customError) Error() string {
+	return fmt.Sprintf("custom error: %d", e.Code)
+}
+
+func TestErrorIsPredicate(t *testing.T) {
+	target := errors.New("boom")
+	wrapped := fmt.Errorf("context: %w", target)
+	predicate := &ErrorIsPredicate{Target: target}
+	if !predicate.Apply(wrapped) {
+		t.Errorf("expected ErrorIsPredicate to match a wrapped sentinel error")
+	}
+	if predicate.Apply(errors.New("boom")) {
+		t.Errorf("expected ErrorIsPredicate to reject a distinct error with the same message")
+	}
+}
+
+func TestErrorMessagePredicate(t *testing.T) {
+	predicate := &errorMessagePredicate{Message: "boom"}
+	if !predicate.Apply(errors.New("boom")) {
+		t.Errorf("expected errorMessagePredicate to match an error with the same message")
+	}
+	if !predicate.Apply(fmt.Errorf("wrapped: %w", errors.New("boom"))) {
+		t.Errorf("expected errorMessagePredicate to match by walking the error chain")
+	}
+	if predicate.Apply(errors.New("other")) {
+		t.Errorf("expected errorMessagePredicate to reject a differing message")
+	}
+}
+
+func TestErrorAsPredicate(t *testing.T) {
+	target := &customError{Code: 42}
+	wrapped := fmt.Errorf("context: %w", target)
+	predicate := &ErrorAsPredicate{TargetType: reflect.TypeOf(target)}
+	if !predicate.Apply(wrapped) {
+		t.Errorf("expected ErrorAsPredicate to match a wrapped error assignable to TargetType")
+	}
+	if predicate.Apply(errors.New("boom")) {
+		t.Errorf("expected ErrorAsPredicate to reject an error not assignable to TargetType")
+	}
+
+	withExpected := &ErrorAsPredicate{TargetType: reflect.TypeOf(target), Expected: map[string]interface{}{"Code": 42.0}}
+	if !withExpected.Apply(wrapped) {
+		t.Errorf("expected ErrorAsPredicate to match the extracted error against Expected")
+	}
+
+	mismatched := &ErrorAsPredicate{TargetType: reflect.TypeOf(target), Expected: map[string]interface{}{"Code": 7.0}}
+	if mismatched.Apply(wrapped) {
+		t.Errorf("expected ErrorAsPredicate to fail when the extracted error doesn't match Expected")
+	}
+}
+
+func TestPanicsWithPredicate(t *testing.T) {
+	predicate := &PanicsWithPredicate{Expected: "boom"}
+	if !predicate.Apply(func() { panic("boom") }) {
+		t.Errorf("expected PanicsWithPredicate to match a panic value equal to Expected")
+	}
+	if predicate.Apply(func() { panic("other") }) {
+		t.Errorf("expected PanicsWithPredicate to reject a differing panic value")
+	}
+	if predicate.Apply(func() {}) {
+		t.Errorf("expected PanicsWithPredicate to reject a func that doesn't panic")
+	}
+	if predicate.Apply("not a func") {
+		t.Errorf("expected PanicsWithPredicate to reject a non-func actual")
+	}
+}
+
+func TestExpandPredicateMacro(t *testing.T) {
+	predicate, ok := expandPredicateMacro("@errorIs@ io.EOF")
+	if !ok {
+		t.Fatalf("expected @errorIs@ io.EOF to be recognized as a macro")
+	}
+	errorIs, ok := predicate.(*ErrorIsPredicate)
+	if !ok || errorIs.Target != io.EOF {
+		t.Errorf("expected @errorIs@ io.EOF to resolve to the io.EOF sentinel, got %#v", predicate)
+	}
+
+	predicate, ok = expandPredicateMacro("@errorIs@ custom failure")
+	if !ok {
+		t.Fatalf("expected unregistered @errorIs@ names to still resolve to a predicate")
+	}
+	if _, ok := predicate.(*errorMessagePredicate); !ok {
+		t.Errorf("expected unregistered @errorIs@ names to fall back to message matching, got %#v", predicate)
+	}
+}
@@ -0,0 +1,161 @@
+package assertly
+
+import (
+	"fmt"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/pmezard/go-difflib/difflib"
+	"reflect"
+	"strings"
+)
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+)
+
+//defaultDiffContextLines controls how many unchanged lines surround each diff hunk
+const defaultDiffContextLines = 3
+
+//ReportOptions controls how Validation.Report renders failures
+type ReportOptions struct {
+	//ElideDepth, when > 0, collapses subtrees identical in expected and actual and nested at least this many levels deep into a "..." placeholder; differing subtrees are kept
+	ElideDepth int
+	//MaxSize caps the rendered report size in bytes; output beyond the cap is truncated with a notice
+	MaxSize int
+	//ContextLines is the number of unchanged lines shown around each diff hunk, defaults to defaultDiffContextLines
+	ContextLines int
+}
+
+var spewConfig = &spew.ConfigState{
+	Indent:                  "  ",
+	SortKeys:                true,
+	DisableMethods:          true,
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+}
+
+//dump pretty prints value with stable key ordering
+func dump(value interface{}) string {
+	return spewConfig.Sdump(value)
+}
+
+//elideUnchanged walks expected/actual in lockstep, collapsing subtrees identical on both sides once depth levels have been descended
+func elideUnchanged(expected, actual interface{}, depth int) (interface{}, interface{}) {
+	if depth <= 0 && reflect.DeepEqual(expected, actual) {
+		return "...", "..."
+	}
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return expected, actual
+		}
+		elidedExpected := make(map[string]interface{}, len(expectedValue))
+		elidedActual := make(map[string]interface{}, len(actualValue))
+		for key, value := range expectedValue {
+			if other, ok := actualValue[key]; ok {
+				elidedExpected[key], elidedActual[key] = elideUnchanged(value, other, depth-1)
+			} else {
+				elidedExpected[key] = value
+			}
+		}
+		for key, value := range actualValue {
+			if _, ok := expectedValue[key]; !ok {
+				elidedActual[key] = value
+			}
+		}
+		return elidedExpected, elidedActual
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			return expected, actual
+		}
+		elidedExpected := make([]interface{}, len(expectedValue))
+		elidedActual := make([]interface{}, len(actualValue))
+		for i, value := range expectedValue {
+			if i < len(actualValue) {
+				elidedExpected[i], elidedActual[i] = elideUnchanged(value, actualValue[i], depth-1)
+			} else {
+				elidedExpected[i] = value
+			}
+		}
+		for i := len(expectedValue); i < len(actualValue); i++ {
+			elidedActual[i] = actualValue[i]
+		}
+		return elidedExpected, elidedActual
+	default:
+		return expected, actual
+	}
+}
+
+//colorize applies ANSI coloring to a unified diff: removed lines in red, added lines in green, headers in bold/cyan
+func colorize(diffText string) string {
+	lines := strings.Split(diffText, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---"):
+			lines[i] = ansiBold + line + ansiReset
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = ansiCyan + line + ansiReset
+		case strings.HasPrefix(line, "+"):
+			lines[i] = ansiGreen + line + ansiReset
+		case strings.HasPrefix(line, "-"):
+			lines[i] = ansiRed + line + ansiReset
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+//Diff renders a unified diff of the failure's expected and actual subtree, headed by its JSON-pointer-style path
+func (f *Failure) Diff() string {
+	return diffValues(f.Path, f.Expected, f.Actual, ReportOptions{})
+}
+
+func diffValues(path string, expected, actual interface{}, options ReportOptions) string {
+	contextLines := options.ContextLines
+	if contextLines == 0 {
+		contextLines = defaultDiffContextLines
+	}
+	if options.ElideDepth > 0 {
+		expected, actual = elideUnchanged(expected, actual, options.ElideDepth)
+	}
+	expectedText := dump(expected)
+	actualText := dump(actual)
+	unified := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(expectedText),
+		B:        difflib.SplitLines(actualText),
+		FromFile: "expected",
+		ToFile:   "actual",
+		Context:  contextLines,
+	}
+	text, err := difflib.GetUnifiedDiffString(unified)
+	if err != nil {
+		text = fmt.Sprintf("--- expected\n+++ actual\n%v\n%v\n", expectedText, actualText)
+	}
+	pointer := path
+	if !strings.HasPrefix(pointer, "/") {
+		pointer = "/" + pointer
+	}
+	return fmt.Sprintf("%v\n%v", pointer, colorize(text))
+}
+
+//Report renders a unified diff for every failure recorded on the validation, applying the supplied options
+func (v *Validation) Report(options ReportOptions) string {
+	if len(v.Failures) == 0 {
+		return ""
+	}
+	var builder strings.Builder
+	for _, failure := range v.Failures {
+		diff := diffValues(failure.Path, failure.Expected, failure.Actual, options)
+		if options.MaxSize > 0 && builder.Len()+len(diff) > options.MaxSize {
+			builder.WriteString("... report truncated, exceeded MaxSize\n")
+			break
+		}
+		builder.WriteString(diff)
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
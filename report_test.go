@@ -0,0 +1,65 @@
+package assertly
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestElideUnchangedPreservesDifferingSubtree(t *testing.T) {
+	expected := map[string]interface{}{
+		"id": 1,
+		"nested": map[string]interface{}{
+			"deep": map[string]interface{}{
+				"unchanged": "same",
+				"changed":   "expectedValue",
+			},
+		},
+	}
+	actual := map[string]interface{}{
+		"id": 1,
+		"nested": map[string]interface{}{
+			"deep": map[string]interface{}{
+				"unchanged": "same",
+				"changed":   "actualValue",
+			},
+		},
+	}
+
+	elidedExpected, elidedActual := elideUnchanged(expected, actual, 1)
+
+	expectedDeep := elidedExpected.(map[string]interface{})["nested"].(map[string]interface{})["deep"]
+	actualDeep := elidedActual.(map[string]interface{})["nested"].(map[string]interface{})["deep"]
+
+	if expectedDeep == "..." || actualDeep == "..." {
+		t.Errorf("expected the differing subtree to survive eliding, got expected=%v actual=%v", expectedDeep, actualDeep)
+	}
+	expectedDeepMap, ok := expectedDeep.(map[string]interface{})
+	if !ok || expectedDeepMap["changed"] != "expectedValue" {
+		t.Errorf("expected the differing field to remain intact, got %v", expectedDeep)
+	}
+}
+
+func TestElideUnchangedCollapsesIdenticalSubtree(t *testing.T) {
+	shared := map[string]interface{}{"a": map[string]interface{}{"b": "same"}}
+	elidedExpected, elidedActual := elideUnchanged(shared, shared, 1)
+	if elidedExpected.(map[string]interface{})["a"] != "..." {
+		t.Errorf("expected an identical subtree beyond depth to collapse to a placeholder, got %v", elidedExpected)
+	}
+	if elidedActual.(map[string]interface{})["a"] != "..." {
+		t.Errorf("expected an identical subtree beyond depth to collapse to a placeholder, got %v", elidedActual)
+	}
+}
+
+func TestColorize(t *testing.T) {
+	diff := "--- expected\n+++ actual\n@@ -1 +1 @@\n-old\n+new\n"
+	colored := colorize(diff)
+	if !strings.Contains(colored, ansiRed+"-old"+ansiReset) {
+		t.Errorf("expected removed line to be colored red, got %v", colored)
+	}
+	if !strings.Contains(colored, ansiGreen+"+new"+ansiReset) {
+		t.Errorf("expected added line to be colored green, got %v", colored)
+	}
+	if !strings.Contains(colored, ansiCyan+"@@ -1 +1 @@"+ansiReset) {
+		t.Errorf("expected hunk header to be colored cyan, got %v", colored)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/viant/toolbox"
 	"log"
+	"math"
 	"path"
 	"reflect"
 	"regexp"
@@ -30,19 +31,73 @@ const (
 	DoesNotContainViolation       = "should not contain fragment"
 	PredicateViolation            = "should pass predicate"
 	ValueWasNil                   = "should have not nil"
+	ToleranceViolation            = "value was outside of tolerance"
 	SharedSwitchCaseKey           = "shared"
 )
 
+//toleranceExpression matches inline numeric tolerance expressions, i.e. "~= 3.14 ± 0.01" or "~= 3.14 +/- 0.01"
+var toleranceExpression = regexp.MustCompile(`^~=\s*(-?[0-9.eE+-]+)\s*(?:±|\+/-)\s*(-?[0-9.eE+-]+)\s*$`)
+
+//tolerance captures an absolute (delta) or relative (epsilon) numeric tolerance resolved for a given path
+type tolerance struct {
+	value      float64
+	delta      float64
+	hasDelta   bool
+	epsilon    float64
+	hasEpsilon bool
+}
+
+func newDeltaTolerance(value, delta float64) *tolerance {
+	return &tolerance{value: value, delta: delta, hasDelta: true}
+}
+
+func newEpsilonTolerance(value, epsilon float64) *tolerance {
+	return &tolerance{value: value, epsilon: epsilon, hasEpsilon: true}
+}
+
+func (t *tolerance) accepts(actual float64) bool {
+	diff := math.Abs(t.value - actual)
+	if t.hasDelta {
+		return diff <= t.delta
+	}
+	if t.hasEpsilon {
+		base := math.Max(math.Abs(t.value), math.Abs(actual))
+		if base == 0 {
+			return diff == 0
+		}
+		return diff/base <= t.epsilon
+	}
+	return false
+}
+
+func (t *tolerance) String() string {
+	if t.hasDelta {
+		return fmt.Sprintf("delta: %v", t.delta)
+	}
+	return fmt.Sprintf("epsilon: %v", t.epsilon)
+}
+
 //Assert validates expected against actual data structure for supplied path
 func Assert(expected, actual interface{}, path DataPath) (*Validation, error) {
 	context := NewDefaultContext()
 	return AssertWithContext(expected, actual, path, context)
 }
 
+//handleFailure reports t.Fail() along with a rendered diff derived from any *Validation found among args
 func handleFailure(t *testing.T, args ...interface{}) {
 	file, method, line := toolbox.DiscoverCaller(2, 10, "assert.go", "stack_helper.go", "validator.go")
 	_, file = path.Split(file)
-	fmt.Printf("%v:%v (%v)\n%v\n", file, line, method, fmt.Sprint(args))
+	report := ""
+	for _, arg := range args {
+		if validation, ok := arg.(*Validation); ok && validation != nil {
+			report = validation.Report(ReportOptions{ElideDepth: 6, MaxSize: 32 * 1024})
+			break
+		}
+	}
+	if report == "" {
+		report = fmt.Sprint(args)
+	}
+	fmt.Printf("%v:%v (%v)\n%v\n", file, line, method, report)
 	t.Fail()
 }
 
@@ -67,6 +122,7 @@ func expandExpectedText(text string, path DataPath, context *Context) (interface
 	if toolbox.IsCompleteJSON(text) {
 		return asDataStructure(text), nil
 	}
+	//context.Evaluator is consulted first so a user-registered macro takes precedence over expandPredicateMacro below
 	if context.Evaluator.HasMacro(text) {
 		evaluated, err := context.Evaluator.Expand(context.Context, text)
 		if err != nil {
@@ -77,6 +133,9 @@ func expandExpectedText(text string, path DataPath, context *Context) (interface
 		}
 		text = toolbox.AsString(evaluated)
 	}
+	if predicate, ok := expandPredicateMacro(text); ok {
+		return predicate, nil
+	}
 	return text, nil
 }
 
@@ -270,6 +329,17 @@ func assertContains(isNegated bool, expected, actual string, path DataPath, cont
 
 func assertText(expected, actual string, path DataPath, context *Context, validation *Validation) error {
 	expected = strings.TrimSpace(expected)
+	if matches := toleranceExpression.FindStringSubmatch(expected); matches != nil {
+		expectedValue := toolbox.AsFloat(matches[1])
+		tol := newDeltaTolerance(expectedValue, toolbox.AsFloat(matches[2]))
+		actualValue := toolbox.AsFloat(actual)
+		if tol.accepts(actualValue) {
+			validation.PassedCount++
+			return nil
+		}
+		validation.AddFailure(NewFailure(path.Source(), path.Path(), ToleranceViolation, expectedValue, actualValue, tol.String()))
+		return nil
+	}
 	if strings.HasSuffix(expected, "/") {
 		expected, isNegated := isNegated(expected)
 		isRegExpr := strings.HasPrefix(expected, "~/")
@@ -316,6 +386,75 @@ func actualMap(expected, actualValue interface{}, path DataPath, directive *Dire
 	return actual
 }
 
+//deltaDirectivePrefix and epsilonDirectivePrefix mark per-field tolerance keys on an expected map, e.g. "@delta@amount": 0.01
+const (
+	deltaDirectivePrefix   = "@delta@"
+	epsilonDirectivePrefix = "@epsilon@"
+)
+
+//elementsMatchDirectiveKey, set to true on a slice's first (directive) element, enables order-independent matching
+const elementsMatchDirectiveKey = "@elementsMatch@"
+
+//extractElementsMatchFlag pops the @elementsMatch@ key off first, if present, reporting its boolean value
+func extractElementsMatchFlag(first map[string]interface{}) (matches bool, ok bool) {
+	value, ok := first[elementsMatchDirectiveKey]
+	if !ok {
+		return false, false
+	}
+	delete(first, elementsMatchDirectiveKey)
+	return toolbox.AsBoolean(value), true
+}
+
+//extractElementsMatchFieldDirectives populates directive.ElementsMatchFields from "@elementsMatch@<field>" keys on expected
+func extractElementsMatchFieldDirectives(directive *Directive, expected map[string]interface{}) {
+	extractFieldDirective(expected, elementsMatchDirectiveKey, func(field string, value interface{}) {
+		if field == "" {
+			return
+		}
+		if directive.ElementsMatchFields == nil {
+			directive.ElementsMatchFields = make(map[string]bool)
+		}
+		directive.ElementsMatchFields[field] = toolbox.AsBoolean(value)
+	})
+}
+
+//extractFieldDirective pulls every key of expected prefixed by prefix (e.g. "@delta@amount") out of the map
+func extractFieldDirective(expected map[string]interface{}, prefix string, assign func(field string, value interface{})) {
+	for key, value := range expected {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		assign(strings.TrimPrefix(key, prefix), value)
+		delete(expected, key)
+	}
+}
+
+//extractToleranceDirectives populates directive.Delta/Epsilon from "@delta@<field>"/"@epsilon@<field>" keys on expected
+func extractToleranceDirectives(directive *Directive, expected map[string]interface{}) {
+	extractFieldDirective(expected, deltaDirectivePrefix, func(field string, value interface{}) {
+		if directive.Delta == nil {
+			directive.Delta = make(map[string]float64)
+		}
+		directive.Delta[field] = toolbox.AsFloat(value)
+	})
+	extractFieldDirective(expected, epsilonDirectivePrefix, func(field string, value interface{}) {
+		if directive.Epsilon == nil {
+			directive.Epsilon = make(map[string]float64)
+		}
+		directive.Epsilon[field] = toolbox.AsFloat(value)
+	})
+}
+
+//assertTolerance validates actual against the tolerance resolved for an expected field
+func assertTolerance(tol *tolerance, actual interface{}, path DataPath, validation *Validation) {
+	actualFloat := toolbox.AsFloat(actual)
+	if tol.accepts(actualFloat) {
+		validation.PassedCount++
+		return
+	}
+	validation.AddFailure(NewFailure(path.Source(), path.Path(), ToleranceViolation, tol.value, actual, tol.String()))
+}
+
 func assertInt(expected, actual interface{}, path DataPath, context *Context, validation *Validation) {
 	expectedInt, err := toolbox.ToInt(expected)
 	if err != nil {
@@ -339,11 +478,12 @@ func assertInt(expected, actual interface{}, path DataPath, context *Context, va
 
 func assertFloat(expected, actual interface{}, path DataPath, context *Context, validation *Validation) {
 	expectedFloat, err := toolbox.ToFloat(expected)
-	isEqual := err == nil && expectedFloat == toolbox.AsFloat(actual)
+	actualFloat := toolbox.AsFloat(actual)
+	isEqual := err == nil && expectedFloat == actualFloat
 	if !isEqual {
 
 		if text, ok := expected.(string); ok {
-			if strings.HasPrefix(text, "/") || strings.HasPrefix(text, "!") {
+			if strings.HasPrefix(text, "/") || strings.HasPrefix(text, "!") || toleranceExpression.MatchString(strings.TrimSpace(text)) {
 				assertText(toolbox.AsString(expected), toolbox.AsString(actual), path, context, validation)
 				return
 			}
@@ -366,6 +506,9 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 	directive := NewDirective(path)
 	directive.mergeFrom(path.Match(context))
 	directive.ExtractDirectives(expected)
+	extractToleranceDirectives(directive, expected)
+	extractFormatDirectives(directive, expected)
+	extractElementsMatchFieldDirectives(directive, expected)
 	path.SetSource(directive.Source)
 	var actual = actualMap(expected, actualValue, path, directive, validation)
 	if actual == nil {
@@ -443,6 +586,30 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 			validation.AddFailure(NewFailure(keyPath.Source(), keyPath.Path(), MissingEntryViolation, expectedValue, toolbox.MapKeysToStringSlice(actual), key))
 			continue
 		}
+		if format, ok := directive.Format[expectedKey]; ok {
+			if err := assertFormatted(format, expectedValue, actualValue, keyPath, context, validation); err != nil {
+				return err
+			}
+			continue
+		}
+		if directive.ElementsMatchFields[expectedKey] {
+			if !toolbox.IsSlice(expectedValue) || !toolbox.IsSlice(actualValue) {
+				validation.AddFailure(NewFailure(keyPath.Source(), keyPath.Path(), IncompatibleDataTypeViolation, expectedValue, actualValue))
+				continue
+			}
+			if err := assertElementsMatch(toolbox.AsSlice(expectedValue), toolbox.AsSlice(actualValue), keyPath, context, validation); err != nil {
+				return err
+			}
+			continue
+		}
+		if delta, ok := directive.Delta[expectedKey]; ok {
+			assertTolerance(newDeltaTolerance(toolbox.AsFloat(expectedValue), delta), actualValue, keyPath, validation)
+			continue
+		}
+		if epsilon, ok := directive.Epsilon[expectedKey]; ok {
+			assertTolerance(newEpsilonTolerance(toolbox.AsFloat(expectedValue), epsilon), actualValue, keyPath, validation)
+			continue
+		}
 		if err := assertValue(expectedValue, actualValue, keyPath, context, validation); err != nil {
 			return err
 		}
@@ -488,9 +655,20 @@ func assertSlice(expected []interface{}, actualValue interface{}, path DataPath,
 
 	if toolbox.IsMap(expected[0]) || toolbox.IsStruct(expected[0]) {
 		first := toolbox.AsMap(expected[0])
-		if directive.ExtractDirectives(first) {
+		matchesAll, hasElementsMatch := extractElementsMatchFlag(first)
+		if hasElementsMatch {
+			directive.ElementsMatch = matchesAll
+		}
+		if extracted := directive.ExtractDirectives(first); extracted || (hasElementsMatch && len(first) == 0) {
 			expected = expected[1:]
 		}
+	}
+
+	if directive.ElementsMatch {
+		return assertElementsMatch(expected, actual, path, context, validation)
+	}
+
+	if len(expected) > 0 && (toolbox.IsMap(expected[0]) || toolbox.IsStruct(expected[0])) {
 
 		if directive.SortText {
 			var expectedSlice = []string{}
@@ -563,3 +741,66 @@ func assertSlice(expected []interface{}, actualValue interface{}, path DataPath,
 	}
 	return nil
 }
+
+//matches reports whether expected can be asserted against actual without producing a failure
+func matches(expected, actual interface{}, path DataPath, context *Context) bool {
+	throwaway := NewValidation()
+	if err := assertValue(expected, actual, path, context, throwaway); err != nil {
+		return false
+	}
+	return len(throwaway.Failures) == 0
+}
+
+//augment tries to find an unmatched actual element for expected[i], extending the matching via augmenting paths
+func augment(i int, expected []interface{}, candidates [][]int, matchedBy []int, visited []bool, path DataPath, context *Context) bool {
+	for _, j := range candidates[i] {
+		if visited[j] {
+			continue
+		}
+		visited[j] = true
+		if matchedBy[j] == -1 || augment(matchedBy[j], expected, candidates, matchedBy, visited, path, context) {
+			matchedBy[j] = i
+			return true
+		}
+	}
+	return false
+}
+
+//assertElementsMatch implements an order independent, multiset comparison of expected against actual
+func assertElementsMatch(expected []interface{}, actual []interface{}, path DataPath, context *Context, validation *Validation) error {
+	candidates := make([][]int, len(expected))
+	for i, expectedItem := range expected {
+		for j, actualItem := range actual {
+			if matches(expectedItem, actualItem, path.Index(i), context) {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+
+	matchedBy := make([]int, len(actual))
+	for j := range matchedBy {
+		matchedBy[j] = -1
+	}
+
+	var unmatchedExpected []interface{}
+	for i := range expected {
+		visited := make([]bool, len(actual))
+		if !augment(i, expected, candidates, matchedBy, visited, path, context) {
+			unmatchedExpected = append(unmatchedExpected, expected[i])
+		}
+	}
+
+	var unmatchedActual []interface{}
+	for j, i := range matchedBy {
+		if i == -1 {
+			unmatchedActual = append(unmatchedActual, actual[j])
+		}
+	}
+
+	if len(unmatchedExpected) == 0 && len(unmatchedActual) == 0 {
+		validation.PassedCount += len(expected)
+		return nil
+	}
+	validation.AddFailure(NewFailure(path.Source(), path.Path(), MissingEntryViolation, unmatchedExpected, unmatchedActual))
+	return nil
+}
@@ -0,0 +1,189 @@
+package assertly
+
+import (
+	"testing"
+)
+
+func TestExtractFieldDirective(t *testing.T) {
+	expected := map[string]interface{}{
+		"amount":        100.0,
+		"@delta@amount": 0.01,
+		"count":         5,
+	}
+	var deltas = make(map[string]float64)
+	extractFieldDirective(expected, deltaDirectivePrefix, func(field string, value interface{}) {
+		deltas[field] = value.(float64)
+	})
+	if _, ok := expected["@delta@amount"]; ok {
+		t.Errorf("expected @delta@amount to be removed from expected map")
+	}
+	if deltas["amount"] != 0.01 {
+		t.Errorf("expected delta for amount to be 0.01, got %v", deltas["amount"])
+	}
+	if _, ok := expected["amount"]; !ok {
+		t.Errorf("expected non directive keys to remain untouched")
+	}
+}
+
+func TestExtractElementsMatchFlag(t *testing.T) {
+	first := map[string]interface{}{
+		"@elementsMatch@": true,
+	}
+	matches, ok := extractElementsMatchFlag(first)
+	if !ok || !matches {
+		t.Errorf("expected @elementsMatch@ to be extracted as true")
+	}
+	if _, ok := first["@elementsMatch@"]; ok {
+		t.Errorf("expected @elementsMatch@ to be removed from first element map")
+	}
+
+	if _, ok := extractElementsMatchFlag(map[string]interface{}{}); ok {
+		t.Errorf("expected no flag to be reported when @elementsMatch@ is absent")
+	}
+}
+
+func TestToleranceAccepts(t *testing.T) {
+	var useCases = []struct {
+		description string
+		tolerance   *tolerance
+		actual      float64
+		expect      bool
+	}{
+		{"within delta", newDeltaTolerance(3.14, 0.01), 3.145, true},
+		{"outside delta", newDeltaTolerance(3.14, 0.01), 3.2, false},
+		{"within epsilon", newEpsilonTolerance(100, 0.01), 100.5, true},
+		{"outside epsilon", newEpsilonTolerance(100, 0.01), 110, false},
+		{"exact zero delta matches equal value", newDeltaTolerance(3.14, 0), 3.14, true},
+		{"exact zero delta rejects a near miss", newDeltaTolerance(3.14, 0), 3.15, false},
+		{"exact zero epsilon matches equal value", newEpsilonTolerance(100, 0), 100, true},
+		{"exact zero epsilon rejects a near miss", newEpsilonTolerance(100, 0), 100.5, false},
+	}
+	for _, useCase := range useCases {
+		actual := useCase.tolerance.accepts(useCase.actual)
+		if actual != useCase.expect {
+			t.Errorf("[%v] expected %v, got %v", useCase.description, useCase.expect, actual)
+		}
+	}
+}
+
+func TestAssertDeltaEpsilonDirectives(t *testing.T) {
+	var useCases = []struct {
+		description string
+		expected    map[string]interface{}
+		actual      map[string]interface{}
+		expectPass  bool
+	}{
+		{
+			description: "@delta@ directive accepts a value within the absolute tolerance",
+			expected:    map[string]interface{}{"amount": 3.14, "@delta@amount": 0.01},
+			actual:      map[string]interface{}{"amount": 3.145},
+			expectPass:  true,
+		},
+		{
+			description: "@delta@ directive rejects a value outside the absolute tolerance",
+			expected:    map[string]interface{}{"amount": 3.14, "@delta@amount": 0.01},
+			actual:      map[string]interface{}{"amount": 3.5},
+			expectPass:  false,
+		},
+		{
+			description: "@epsilon@ directive accepts a value within the relative tolerance",
+			expected:    map[string]interface{}{"amount": 100.0, "@epsilon@amount": 0.01},
+			actual:      map[string]interface{}{"amount": 100.5},
+			expectPass:  true,
+		},
+		{
+			description: "@epsilon@ directive rejects a value outside the relative tolerance",
+			expected:    map[string]interface{}{"amount": 100.0, "@epsilon@amount": 0.01},
+			actual:      map[string]interface{}{"amount": 150.0},
+			expectPass:  false,
+		},
+		{
+			description: "explicit zero @delta@ still requires an exact match, not an unconditional failure",
+			expected:    map[string]interface{}{"amount": 100.0, "@delta@amount": 0.0},
+			actual:      map[string]interface{}{"amount": 100.0},
+			expectPass:  true,
+		},
+	}
+	for _, useCase := range useCases {
+		validation, err := Assert(useCase.expected, useCase.actual, NewDataPath(""))
+		if err != nil {
+			t.Errorf("[%v] unexpected error: %v", useCase.description, err)
+			continue
+		}
+		passed := len(validation.Failures) == 0
+		if passed != useCase.expectPass {
+			t.Errorf("[%v] expected pass=%v, got failures=%v", useCase.description, useCase.expectPass, validation.Failures)
+		}
+	}
+}
+
+func TestAssertElementsMatchOnSliceFirstElement(t *testing.T) {
+	expected := []interface{}{
+		map[string]interface{}{elementsMatchDirectiveKey: true},
+		"b", "a", "c",
+	}
+	actual := []interface{}{"a", "b", "c"}
+	validation, err := Assert(expected, actual, NewDataPath(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) != 0 {
+		t.Errorf("expected @elementsMatch@ on the slice's first element to pass regardless of order, got failures: %v", validation.Failures)
+	}
+}
+
+func TestAssertElementsMatchOnParentMapKey(t *testing.T) {
+	expected := map[string]interface{}{
+		"items":                   []interface{}{"b", "a", "c"},
+		"@elementsMatch@items": true,
+	}
+	actual := map[string]interface{}{
+		"items": []interface{}{"a", "b", "c"},
+	}
+	validation, err := Assert(expected, actual, NewDataPath(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) != 0 {
+		t.Errorf("expected @elementsMatch@<field> on the parent map key to pass regardless of order, got failures: %v", validation.Failures)
+	}
+
+	mismatched := map[string]interface{}{
+		"items": []interface{}{"a", "b", "x"},
+	}
+	//the first Assert consumed the directive key, so it is declared again
+	expected["@elementsMatch@items"] = true
+	validation, err = Assert(expected, mismatched, NewDataPath(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) == 0 {
+		t.Errorf("expected @elementsMatch@<field> to still fail when the multisets differ")
+	}
+}
+
+func TestAssertInlineToleranceExpression(t *testing.T) {
+	validation, err := Assert("~= 3.14 ± 0.01", 3.145, NewDataPath(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) != 0 {
+		t.Errorf("expected inline tolerance expression to pass, got failures: %v", validation.Failures)
+	}
+
+	validation, err = Assert("~= 3.14 ± 0.01", 4.0, NewDataPath(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) == 0 {
+		t.Errorf("expected inline tolerance expression to fail for a value outside tolerance")
+	}
+
+	validation, err = Assert("~= 3 ± 1", 4, NewDataPath(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(validation.Failures) != 0 {
+		t.Errorf("expected inline tolerance expression to pass for an int actual, got failures: %v", validation.Failures)
+	}
+}